@@ -4,10 +4,13 @@ import (
 	"encoding/csv"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -18,88 +21,280 @@ import (
 )
 
 type AllocationCalculator struct {
-	currentBalances []float64
+	// balancesMu guards currentBalances and lastUpdated, which the live
+	// price refresh goroutine writes on a timer while the UI goroutine
+	// reads and replaces them from button callbacks.
+	balancesMu      sync.Mutex
+	currentBalances map[string]float64
 	infoTable       [][]string
+	resultsTable    [][]string
 	targetValues    []float64
 	filename        string
+	profile         Profile
+	ledgerPath      string
+	positions       map[string]*Position
+	priceProvider   PriceProvider
+	holdings        map[string]float64
+	lastUpdated     map[string]time.Time
+	ledgerEntries   []Purchase
+	openLots        map[string][]Lot
+	lotPolicy       LotPolicy
+	accounts        []AccountState
+}
+
+// calculateMultiAccountStrategy coordinates rebalancing across ac.accounts
+// and renders each account's recommended trades grouped under its name.
+func (ac *AllocationCalculator) calculateMultiAccountStrategy() string {
+	plans := PlanAccounts(ac.accounts, ac.positions)
+
+	result := strings.Builder{}
+	for _, plan := range plans {
+		fmt.Fprintf(&result, "Account: %s\n", plan.Account)
+		for _, trade := range plan.Trades {
+			fmt.Fprintf(&result, "  %s $%.2f %s (%s)\n", trade.Action, trade.Amount, trade.Symbol, trade.Note)
+		}
+	}
+	return result.String()
+}
+
+// refreshBalances re-prices every held symbol through ac.priceProvider and
+// sets each quoted symbol's currentBalances entry to shares * price; symbols
+// it could not quote keep their previous value, so calculateStrategy can
+// run against live quotes instead of only a scraped CSV snapshot.
+func (ac *AllocationCalculator) refreshBalances() error {
+	if ac.priceProvider == nil {
+		return fmt.Errorf("no price provider configured")
+	}
+
+	// Fetch quotes without holding the lock, since each FetchPrice call is
+	// a network round trip and would otherwise stall calculateStrategy or
+	// scrapeValuesFromCSV on the UI goroutine for the whole refresh.
+	balances := map[string]float64{}
+	var firstErr error
+	for symbol, shares := range ac.holdings {
+		price, err := ac.priceProvider.FetchPrice(symbol)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		balances[symbol] = shares * price
+	}
+
+	ac.balancesMu.Lock()
+	defer ac.balancesMu.Unlock()
+	if ac.currentBalances == nil {
+		ac.currentBalances = map[string]float64{}
+	}
+	if ac.lastUpdated == nil {
+		ac.lastUpdated = map[string]time.Time{}
+	}
+	now := time.Now()
+	for symbol, balance := range balances {
+		ac.currentBalances[symbol] = balance
+		ac.lastUpdated[symbol] = now
+	}
+	return firstErr
+}
+
+// loadLedger reads the purchase ledger and recomputes cost-basis positions
+// from it. Call this after the ledger changes (e.g. a new buy/sell is
+// recorded) so the next calculateStrategy reflects it.
+func (ac *AllocationCalculator) loadLedger() error {
+	entries, err := LoadLedger(ac.ledgerPath)
+	if err != nil {
+		return err
+	}
+	ac.ledgerEntries = entries
+	ac.positions = ComputePositions(entries)
+	ac.openLots, err = OpenLots(entries)
+	return err
+}
+
+// describeSell reports which tax lots a recommended sell of `amount`
+// dollars of bucket.Symbol would draw from under ac.lotPolicy, including
+// short/long-term classification and any wash-sale exposure.
+func (ac *AllocationCalculator) describeSell(bucket AssetBucket, amount, current float64) string {
+	pos := ac.positions[bucket.Symbol]
+	if pos == nil || pos.Shares <= 0 {
+		return ""
+	}
+	price := current / pos.Shares
+	shares := amount / price
+
+	sales := SelectLots(ac.openLots[bucket.Symbol], shares, ac.lotPolicy, price, time.Now(), ac.ledgerEntries)
+	if len(sales) == 0 {
+		return ""
+	}
+
+	result := strings.Builder{}
+	for _, sale := range sales {
+		term := "long-term"
+		if sale.ShortTerm {
+			term = "short-term"
+		}
+		result.WriteString(fmt.Sprintf("  Lot %s (%.4f sh @ %.2f, %s): sell %.4f sh for %.2f gain/loss %.2f",
+			sale.Lot.Date.Format("2006-01-02"), sale.Lot.Shares, sale.Lot.Price, term,
+			sale.SharesSold, sale.Proceeds, sale.GainLoss))
+		if sale.WashSale {
+			result.WriteString(" [WASH SALE WARNING]")
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
 }
 
 func (ac *AllocationCalculator) scrapeValuesFromCSV(filename string) error {
-	file, err := os.Open(filename)
+	balances, infoTable, err := scrapeBalancesFromCSV(filename)
 	if err != nil {
 		return err
 	}
+
+	ac.balancesMu.Lock()
+	ac.currentBalances = balances
+	ac.balancesMu.Unlock()
+	ac.infoTable = infoTable
+
+	return nil
+}
+
+// scrapeBalancesFromCSV parses a brokerage CSV export into symbol -> current
+// value, the same way scrapeValuesFromCSV does, without touching an
+// AllocationCalculator. Used to load balances for a single AccountState too.
+func scrapeBalancesFromCSV(filename string) (map[string]float64, [][]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
 	defer file.Close()
 
 	reader := csv.NewReader(file)
 	records, err := reader.ReadAll()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	ac.currentBalances = nil
-	ac.infoTable = [][]string{
+	balances := map[string]float64{}
+	infoTable := [][]string{
 		{"Symbol", "Current Value", "Current Allocation", "Target Value", "Target Allocation"},
 	}
 
-	for i := 2; i < 5; i++ {
+	for i := 2; i < 5 && i < len(records); i++ {
 		valueStr := strings.Trim(records[i][7], "$")
 		value, err := strconv.ParseFloat(valueStr, 64)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		ac.currentBalances = append(ac.currentBalances, value)
-		ac.infoTable = append(ac.infoTable, []string{records[i][2], valueStr})
+		balances[records[i][2]] = value
+		infoTable = append(infoTable, []string{records[i][2], valueStr})
 	}
 
-	return nil
+	return balances, infoTable, nil
 }
 
-func (ac *AllocationCalculator) buyOrSell(percentage, total, current, moneyToInvest float64, name string) string {
-	target := total * percentage
-	actualVsTargetRatio := target / current
+// buyOrSell decides whether a bucket needs a trade: a bucket needs none
+// ("Looks good") when its current value is within driftThreshold of its
+// target and contributions (moneyToInvest) aren't being added this round. Trades are
+// clamped to [minTrade, maxTrade] when those bounds are set (non-zero).
+// It returns the recommended action and, for a sell, the positive dollar
+// amount to sell (0 otherwise).
+func (ac *AllocationCalculator) buyOrSell(bucket AssetBucket, total, current, moneyToInvest float64) (string, float64) {
+	target := total * bucket.TargetPercentage
+	drift := (current - target) / target
 
 	var action string
-	if 0.95 < actualVsTargetRatio && actualVsTargetRatio < 1.05 && int(moneyToInvest) == 0 {
-		action = fmt.Sprintf("Looks good for %s", name)
+	var sellAmount float64
+	if math.Abs(drift) < bucket.DriftThreshold && int(moneyToInvest) == 0 {
+		action = fmt.Sprintf("Looks good for %s", bucket.Symbol)
 	} else {
-		amountToTrade := target - current
+		amountToTrade := clampTrade(target-current, bucket.MinTrade, bucket.MaxTrade)
 		if amountToTrade > 0 {
-			action = fmt.Sprintf("Buy $%.2f %s", amountToTrade, name)
+			action = fmt.Sprintf("Buy $%.2f %s", amountToTrade, bucket.Symbol)
 		} else {
-			action = fmt.Sprintf("Sell $%.2f %s", -amountToTrade, name)
+			sellAmount = -amountToTrade
+			action = fmt.Sprintf("Sell $%.2f %s", sellAmount, bucket.Symbol)
 		}
 	}
 	ac.targetValues = append(ac.targetValues, target)
-	return action
+	return action, sellAmount
+}
+
+// clampTrade bounds a signed trade amount by bucket min/max trade sizes.
+// A zero bound means "no limit", matching the profile file's convention.
+func clampTrade(amount, minTrade, maxTrade float64) float64 {
+	sign := 1.0
+	if amount < 0 {
+		sign = -1.0
+	}
+	magnitude := math.Abs(amount)
+	if minTrade > 0 && magnitude < minTrade {
+		magnitude = minTrade
+	}
+	if maxTrade > 0 && magnitude > maxTrade {
+		magnitude = maxTrade
+	}
+	return sign * magnitude
 }
 
 func (ac *AllocationCalculator) calculateStrategy(moneyToInvest float64) (string, error) {
-	var bondPercentage, intlPercentage, nationalPercentage float64
-	switch {
-	case strings.Contains(ac.filename, "202"):
-		bondPercentage, intlPercentage, nationalPercentage = 0.1, 0.3, 0.6
-	case strings.Contains(ac.filename, "203"):
-		bondPercentage, intlPercentage, nationalPercentage = 0.3, 0.27, 0.43
-	default:
-		bondPercentage, intlPercentage, nationalPercentage = 1.0, 0.0, 0.0
+	if err := ac.profile.Validate(); err != nil {
+		return "", err
 	}
 
+	ac.balancesMu.Lock()
+	currentBalances := make(map[string]float64, len(ac.currentBalances))
+	for symbol, balance := range ac.currentBalances {
+		currentBalances[symbol] = balance
+	}
+	lastUpdated := make(map[string]time.Time, len(ac.lastUpdated))
+	for symbol, t := range ac.lastUpdated {
+		lastUpdated[symbol] = t
+	}
+	ac.balancesMu.Unlock()
+
 	totalAmount := moneyToInvest
-	for _, balance := range ac.currentBalances {
+	for _, balance := range currentBalances {
 		totalAmount += balance
 	}
 
+	ac.resultsTable = [][]string{
+		{"Symbol", "Current Value", "Cost Basis", "Unrealized PnL", "PnL %", "Realized PnL", "Last Updated"},
+	}
+
 	result := strings.Builder{}
-	result.WriteString(fmt.Sprintf("Current Amount In Bonds: %.2f\n", ac.currentBalances[0]))
-	result.WriteString(fmt.Sprintf("Current Amount In International Index: %.2f\n", ac.currentBalances[1]))
-	result.WriteString(fmt.Sprintf("Current Amount In National Index: %.2f\n", ac.currentBalances[2]))
-	result.WriteString(fmt.Sprintf("Bond Strategy: %s\n",
-		ac.buyOrSell(bondPercentage, totalAmount, ac.currentBalances[0], moneyToInvest, "Bonds")))
-	result.WriteString(fmt.Sprintf("Intl Strategy: %s\n",
-		ac.buyOrSell(intlPercentage, totalAmount, ac.currentBalances[1], moneyToInvest, "International Index")))
-	result.WriteString(fmt.Sprintf("National Strategy: %s\n",
-		ac.buyOrSell(nationalPercentage, totalAmount, ac.currentBalances[2], moneyToInvest, "National Index")))
+	for _, bucket := range ac.profile.Buckets {
+		current := currentBalances[bucket.Symbol]
+		result.WriteString(fmt.Sprintf("Current Amount In %s: %.2f\n", bucket.Symbol, current))
+		action, sellAmount := ac.buyOrSell(bucket, totalAmount, current, moneyToInvest)
+		result.WriteString(fmt.Sprintf("%s Strategy: %s\n", bucket.Symbol, action))
+		if sellAmount > 0 {
+			if detail := ac.describeSell(bucket, sellAmount, current); detail != "" {
+				result.WriteString(detail)
+			}
+		}
+
+		pos := ac.positions[bucket.Symbol]
+		if pos == nil {
+			pos = &Position{Symbol: bucket.Symbol}
+		}
+		unrealized, percent := pos.UnrealizedPnL(current)
+		result.WriteString(fmt.Sprintf("%s PnL: %.2f (%.2f%%), Realized: %.2f\n",
+			bucket.Symbol, unrealized, percent, pos.RealizedPnL))
+		updatedText := "never"
+		if t, ok := lastUpdated[bucket.Symbol]; ok {
+			updatedText = t.Format("15:04:05")
+		}
+		ac.resultsTable = append(ac.resultsTable, []string{
+			bucket.Symbol,
+			fmt.Sprintf("%.2f", current),
+			fmt.Sprintf("%.2f", pos.CostBasis),
+			fmt.Sprintf("%.2f", unrealized),
+			fmt.Sprintf("%.2f%%", percent),
+			fmt.Sprintf("%.2f", pos.RealizedPnL),
+			updatedText,
+		})
+	}
 
 	return result.String(), nil
 }
@@ -108,13 +303,124 @@ func main() {
 	app := app.New()
 	window := app.NewWindow("Asset Allocation")
 
-	ac := &AllocationCalculator{}
+	ac := &AllocationCalculator{ledgerPath: defaultLedgerFile, priceProvider: YahooFinanceProvider{}, lotPolicy: LotPolicyFIFO}
+	if err := ac.loadLedger(); err != nil {
+		log.Println("Error loading ledger:", err)
+	}
+	holdings, err := LoadHoldings(defaultHoldingsFile)
+	if err != nil {
+		log.Println("Error loading holdings:", err)
+	}
+	ac.holdings = holdings
+
+	profiles, err := LoadProfiles(defaultProfilesFile)
+	if err != nil {
+		log.Println("Error loading profiles:", err)
+	}
 
 	csvLabel := widget.NewLabel("No CSV file selected")
 	investmentInput := widget.NewEntry()
 	investmentInput.SetPlaceHolder("Enter amount to invest")
 
 	strategyLabel := widget.NewLabel("")
+	bucketsBox := container.NewVBox()
+	lastUpdatedLabel := widget.NewLabel("Live prices: never refreshed")
+
+	profileNames := func() []string {
+		names := make([]string, len(profiles))
+		for i, p := range profiles {
+			names[i] = p.Name
+		}
+		return names
+	}
+
+	// rebuildBucketsBox redraws one editable percentage entry per bucket in
+	// the active profile, so the user can tweak targets inline.
+	var rebuildBucketsBox func()
+	rebuildBucketsBox = func() {
+		bucketsBox.RemoveAll()
+		for i := range ac.profile.Buckets {
+			bucket := &ac.profile.Buckets[i]
+			label := widget.NewLabel(bucket.Symbol)
+			entry := widget.NewEntry()
+			entry.SetText(fmt.Sprintf("%v", bucket.TargetPercentage))
+			entry.OnChanged = func(text string) {
+				if v, err := strconv.ParseFloat(text, 64); err == nil {
+					bucket.TargetPercentage = v
+				}
+			}
+			bucketsBox.Add(container.NewHBox(label, entry))
+		}
+	}
+
+	profileSelect := widget.NewSelect(profileNames(), func(name string) {
+		for _, p := range profiles {
+			if p.Name == name {
+				ac.profile = p
+				rebuildBucketsBox()
+				return
+			}
+		}
+	})
+	if len(profiles) > 0 {
+		profileSelect.SetSelected(profiles[0].Name)
+	}
+
+	refreshIntervalInput := widget.NewEntry()
+	refreshIntervalInput.SetText("60")
+	var stopLiveRefresh func()
+	liveRefreshButton := widget.NewButton("Start Live Prices", nil)
+	liveRefreshButton.OnTapped = func() {
+		if stopLiveRefresh != nil {
+			stopLiveRefresh()
+			stopLiveRefresh = nil
+			liveRefreshButton.SetText("Start Live Prices")
+			return
+		}
+
+		seconds, err := strconv.Atoi(refreshIntervalInput.Text)
+		if err != nil || seconds <= 0 {
+			lastUpdatedLabel.SetText("Invalid refresh interval")
+			return
+		}
+
+		ticker := time.NewTicker(time.Duration(seconds) * time.Second)
+		done := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if err := ac.refreshBalances(); err != nil {
+						log.Println("Error refreshing prices:", err)
+					}
+					refreshedAt := time.Now().Format("15:04:05")
+					// Widgets aren't safe to mutate off the UI goroutine;
+					// marshal the update onto Fyne's main thread.
+					fyne.Do(func() {
+						lastUpdatedLabel.SetText(fmt.Sprintf("Live prices: last refreshed %s", refreshedAt))
+					})
+				}
+			}
+		}()
+		stopLiveRefresh = func() {
+			ticker.Stop()
+			close(done)
+		}
+		liveRefreshButton.SetText("Stop Live Prices")
+	}
+
+	saveProfileButton := widget.NewButton("Save Profile", func() {
+		for i, p := range profiles {
+			if p.Name == ac.profile.Name {
+				profiles[i] = ac.profile
+			}
+		}
+		if err := SaveProfiles(defaultProfilesFile, profiles); err != nil {
+			log.Println("Error saving profiles:", err)
+		}
+	})
 
 	selectCSVButton := widget.NewButton("Select CSV", func() {
 		dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
@@ -130,6 +436,22 @@ func main() {
 		}, window)
 	})
 
+	resultsTable := widget.NewTable(
+		func() (int, int) {
+			if len(ac.resultsTable) == 0 {
+				return 0, 0
+			}
+			return len(ac.resultsTable), len(ac.resultsTable[0])
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(ac.resultsTable[id.Row][id.Col])
+		},
+	)
+	resultsTable.Resize(fyne.NewSize(760, 160))
+
 	calculateButton := widget.NewButton("Calculate Strategy", func() {
 		investment, err := strconv.ParseFloat(investmentInput.Text, 64)
 		if err != nil {
@@ -142,19 +464,171 @@ func main() {
 			return
 		}
 		strategyLabel.SetText(result)
+		resultsTable.Refresh()
 	})
 
 	copyButton := widget.NewButton("Copy Strategy", func() {
 		_ = clipboard.WriteAll(strategyLabel.Text)
 	})
 
+	lotPolicySelect := widget.NewSelect(
+		[]string{string(LotPolicyFIFO), string(LotPolicyLIFO), string(LotPolicyHIFO), string(LotPolicyMinTax)},
+		func(policy string) { ac.lotPolicy = LotPolicy(policy) },
+	)
+	lotPolicySelect.SetSelected(string(LotPolicyFIFO))
+
+	taxReportLabel := widget.NewLabel("")
+	taxReportButton := widget.NewButton("Realized Gains by Year", func() {
+		summary, err := SummarizeRealizedGains(ac.ledgerEntries)
+		if err != nil {
+			taxReportLabel.SetText("Error summarizing realized gains: " + err.Error())
+			return
+		}
+		report := strings.Builder{}
+		for _, year := range summary {
+			fmt.Fprintf(&report, "%d: short-term %.2f, long-term %.2f\n",
+				year.Year, year.ShortTermGain, year.LongTermGain)
+		}
+		taxReportLabel.SetText(report.String())
+	})
+
+	tradeSymbol := widget.NewEntry()
+	tradeSymbol.SetPlaceHolder("Symbol")
+	tradeShares := widget.NewEntry()
+	tradeShares.SetPlaceHolder("Shares (negative to sell)")
+	tradePrice := widget.NewEntry()
+	tradePrice.SetPlaceHolder("Price per share")
+	tradeCurrency := widget.NewEntry()
+	tradeCurrency.SetText("USD")
+
+	recordTradeButton := widget.NewButton("Record Trade", func() {
+		shares, err := strconv.ParseFloat(tradeShares.Text, 64)
+		if err != nil {
+			log.Println("Invalid share count:", err)
+			return
+		}
+		price, err := strconv.ParseFloat(tradePrice.Text, 64)
+		if err != nil {
+			log.Println("Invalid price:", err)
+			return
+		}
+		purchase := Purchase{
+			Symbol:   tradeSymbol.Text,
+			Date:     time.Now().Format("2006-01-02"),
+			Shares:   shares,
+			Price:    price,
+			Currency: tradeCurrency.Text,
+		}
+		if err := AppendPurchase(ac.ledgerPath, purchase); err != nil {
+			log.Println("Error recording trade:", err)
+			return
+		}
+		if err := ac.loadLedger(); err != nil {
+			log.Println("Error reloading ledger:", err)
+		}
+	})
+
+	accountsBox := container.NewVBox()
+	multiAccountLabel := widget.NewLabel("")
+
+	var rebuildAccountsBox func()
+	rebuildAccountsBox = func() {
+		accountsBox.RemoveAll()
+		for i := range ac.accounts {
+			index := i
+			acct := &ac.accounts[index]
+			nameLabel := widget.NewLabel(fmt.Sprintf("%s (%s)", acct.Name, acct.TaxTreatment))
+			cashFlowEntry := widget.NewEntry()
+			cashFlowEntry.SetText(fmt.Sprintf("%v", acct.CashFlow))
+			cashFlowEntry.OnChanged = func(text string) {
+				if v, err := strconv.ParseFloat(text, 64); err == nil {
+					acct.CashFlow = v
+				}
+			}
+			removeButton := widget.NewButton("Remove", func() {
+				ac.accounts = append(ac.accounts[:index], ac.accounts[index+1:]...)
+				rebuildAccountsBox()
+			})
+
+			// One editable balance entry per bucket in this account's
+			// profile, so PlanAccounts has real holdings to rebalance
+			// instead of every bucket reading as permanently empty.
+			balancesBox := container.NewHBox()
+			for _, bucket := range acct.Profile.Buckets {
+				symbol := bucket.Symbol
+				balanceEntry := widget.NewEntry()
+				balanceEntry.SetText(fmt.Sprintf("%v", acct.Balances[symbol]))
+				balanceEntry.OnChanged = func(text string) {
+					if v, err := strconv.ParseFloat(text, 64); err == nil {
+						acct.Balances[symbol] = v
+					}
+				}
+				balancesBox.Add(widget.NewLabel(symbol + ":"))
+				balancesBox.Add(balanceEntry)
+			}
+
+			loadCSVButton := widget.NewButton("Load CSV", func() {
+				dialog.ShowFileOpen(func(reader fyne.URIReadCloser, err error) {
+					if err != nil || reader == nil {
+						return
+					}
+					balances, _, err := scrapeBalancesFromCSV(reader.URI().Path())
+					if err != nil {
+						log.Println("Error reading CSV for account:", err)
+						return
+					}
+					acct.Balances = balances
+					rebuildAccountsBox()
+				}, window)
+			})
+
+			accountsBox.Add(container.NewHBox(nameLabel, widget.NewLabel("Cash Flow:"), cashFlowEntry, loadCSVButton, removeButton))
+			accountsBox.Add(balancesBox)
+		}
+	}
+
+	newAccountName := widget.NewEntry()
+	newAccountName.SetPlaceHolder("Account name")
+	newAccountTreatment := widget.NewSelect([]string{"taxable", "ira", "401k"}, nil)
+	newAccountTreatment.SetSelected("taxable")
+
+	addAccountButton := widget.NewButton("Add Account", func() {
+		if newAccountName.Text == "" || ac.profile.Name == "" {
+			return
+		}
+		ac.accounts = append(ac.accounts, AccountState{
+			Name:         newAccountName.Text,
+			TaxTreatment: newAccountTreatment.Selected,
+			Profile:      ac.profile,
+			Balances:     map[string]float64{},
+		})
+		newAccountName.SetText("")
+		rebuildAccountsBox()
+	})
+
+	calculateMultiAccountButton := widget.NewButton("Calculate Multi-Account Plan", func() {
+		multiAccountLabel.SetText(ac.calculateMultiAccountStrategy())
+	})
+
 	content := container.NewVBox(
+		profileSelect,
+		bucketsBox,
+		saveProfileButton,
 		csvLabel,
 		selectCSVButton,
 		investmentInput,
 		calculateButton,
 		strategyLabel,
 		copyButton,
+		resultsTable,
+		container.NewHBox(tradeSymbol, tradeShares, tradePrice, tradeCurrency, recordTradeButton),
+		container.NewHBox(refreshIntervalInput, liveRefreshButton, lastUpdatedLabel),
+		container.NewHBox(lotPolicySelect, taxReportButton),
+		taxReportLabel,
+		accountsBox,
+		container.NewHBox(newAccountName, newAccountTreatment, addAccountButton),
+		calculateMultiAccountButton,
+		multiAccountLabel,
 	)
 
 	window.SetContent(content)
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// AccountState is one brokerage/retirement account in a multi-account
+// rebalance: its own holdings, its own target Profile (asset location
+// often differs by account — e.g. bonds in an IRA, stock index in
+// taxable), and this period's contribution or withdrawal.
+type AccountState struct {
+	Name            string
+	TaxTreatment    string // "taxable", "ira", "401k", ...
+	Profile         Profile
+	Balances        map[string]float64 // symbol -> current value
+	CashFlow        float64            // positive = contribution, negative = withdrawal
+	MaxRealizedGain float64            // 0 = no cap; only enforced when TaxTreatment == "taxable"
+}
+
+// AccountTrade is one recommended trade within an AccountPlan.
+type AccountTrade struct {
+	Symbol string
+	Action string // "Buy", "Sell", or "Hold"
+	Amount float64
+	Note   string
+}
+
+// AccountPlan groups the trades recommended for a single account.
+type AccountPlan struct {
+	Account string
+	Trades  []AccountTrade
+}
+
+// accountNeed is one bucket's dollar gap between target and current value
+// within a single account.
+type accountNeed struct {
+	account *AccountState
+	bucket  AssetBucket
+	amount  float64 // positive = underweight (buy), negative = overweight (sell)
+}
+
+// PlanAccounts coordinates rebalancing across accounts: within each
+// tax-treatment group (money can't move between a taxable account and an
+// IRA), new contributions and the proceeds of allowed sells are pooled and
+// applied to the group's most underweight buckets first — even in a
+// different account than the one the cash landed in. Every overweight
+// bucket still gets its own Sell (or a Hold when the tax cap blocks it);
+// contributions elsewhere in the group do not reduce or replace those
+// sells. Sells in a taxable account are blocked if they'd realize more
+// gain than MaxRealizedGain.
+func PlanAccounts(accounts []AccountState, positions map[string]*Position) []AccountPlan {
+	groups := map[string][]*AccountState{}
+	var order []string
+	for i := range accounts {
+		treatment := accounts[i].TaxTreatment
+		if _, ok := groups[treatment]; !ok {
+			order = append(order, treatment)
+		}
+		groups[treatment] = append(groups[treatment], &accounts[i])
+	}
+
+	// A symbol's market price is the same everywhere it's held, but no
+	// account tracks its own share count — only its dollar balance. Derive
+	// a consistent price per share from the combined balance across every
+	// account, so each account's share count can be recovered from its own
+	// balance instead of mixing one account's dollars with every account's
+	// shares.
+	pricePerShare := map[string]float64{}
+	totalBySymbol := map[string]float64{}
+	for _, acct := range accounts {
+		for symbol, balance := range acct.Balances {
+			totalBySymbol[symbol] += balance
+		}
+	}
+	for symbol, total := range totalBySymbol {
+		if pos := positions[symbol]; pos != nil && pos.Shares > 0 {
+			pricePerShare[symbol] = total / pos.Shares
+		}
+	}
+
+	tradesByAccount := map[string][]AccountTrade{}
+	for _, treatment := range order {
+		planGroup(groups[treatment], positions, pricePerShare, tradesByAccount)
+	}
+
+	plans := make([]AccountPlan, len(accounts))
+	for i, acct := range accounts {
+		plans[i] = AccountPlan{Account: acct.Name, Trades: tradesByAccount[acct.Name]}
+	}
+	return plans
+}
+
+func planGroup(accounts []*AccountState, positions map[string]*Position, pricePerShare map[string]float64, out map[string][]AccountTrade) {
+	var needs []accountNeed
+	for _, acct := range accounts {
+		total := acct.CashFlow
+		for _, balance := range acct.Balances {
+			total += balance
+		}
+		for _, bucket := range acct.Profile.Buckets {
+			current := acct.Balances[bucket.Symbol]
+			target := total * bucket.TargetPercentage
+			drift := 0.0
+			if target != 0 {
+				drift = (current - target) / target
+			}
+			if math.Abs(drift) < bucket.DriftThreshold && acct.CashFlow == 0 {
+				continue
+			}
+			needs = append(needs, accountNeed{account: acct, bucket: bucket, amount: target - current})
+		}
+	}
+
+	sort.SliceStable(needs, func(i, j int) bool { return needs[i].amount > needs[j].amount })
+
+	// Sells run first so their allowed (tax-cap-checked) proceeds can be
+	// pooled alongside contributions before any buy is funded — a sell
+	// blocked by capSellForTax doesn't free cash, so it must not be
+	// counted as available funding for a buy elsewhere in the group.
+	pool := 0.0
+	for _, acct := range accounts {
+		if acct.CashFlow > 0 {
+			pool += acct.CashFlow
+		}
+	}
+
+	for _, n := range needs {
+		if n.amount >= 0 {
+			continue
+		}
+		amount := -n.amount
+		allowed, note := capSellForTax(n.account, n.bucket.Symbol, amount, positions, pricePerShare)
+		action := "Sell"
+		if allowed == 0 {
+			action = "Hold"
+		}
+		pool += allowed
+		out[n.account.Name] = append(out[n.account.Name], AccountTrade{
+			Symbol: n.bucket.Symbol, Action: action, Amount: allowed, Note: note,
+		})
+	}
+
+	for _, n := range needs {
+		if n.amount <= 0 {
+			continue
+		}
+		fromPool := math.Min(pool, n.amount)
+		pool -= fromPool
+
+		note := fmt.Sprintf("%.2f funded by contributions and sells in this group", fromPool)
+		if shortfall := n.amount - fromPool; shortfall > 0 {
+			note = fmt.Sprintf("%s; UNDERFUNDED by %.2f — manual contribution or sell required in %s",
+				note, shortfall, n.account.Name)
+		}
+		out[n.account.Name] = append(out[n.account.Name], AccountTrade{
+			Symbol: n.bucket.Symbol, Action: "Buy", Amount: n.amount, Note: note,
+		})
+	}
+}
+
+// capSellForTax blocks a sell entirely if it's in a taxable account with a
+// realized-gain cap and the estimated gain would exceed it. pricePerShare
+// must come from PlanAccounts, not acct.Balances[symbol]/positions[symbol].Shares
+// — the latter divides one account's dollar balance by every account's
+// combined share count, which only happens to be correct when a symbol is
+// held in exactly one account.
+func capSellForTax(acct *AccountState, symbol string, amount float64, positions map[string]*Position, pricePerShare map[string]float64) (float64, string) {
+	if acct.TaxTreatment != "taxable" || acct.MaxRealizedGain <= 0 {
+		return amount, ""
+	}
+	pos := positions[symbol]
+	price := pricePerShare[symbol]
+	if pos == nil || pos.Shares <= 0 || price <= 0 {
+		return amount, ""
+	}
+	shares := amount / price
+	gain := (price - pos.AvgCost()) * shares
+	if gain <= acct.MaxRealizedGain {
+		return amount, ""
+	}
+	return 0, fmt.Sprintf("blocked: selling $%.2f %s in %s would realize $%.2f gain, over the $%.2f cap",
+		amount, symbol, acct.Name, gain, acct.MaxRealizedGain)
+}
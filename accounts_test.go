@@ -0,0 +1,95 @@
+package main
+
+import "testing"
+
+func TestCapSellForTaxUsesPerAccountSharesWhenASymbolSpansAccounts(t *testing.T) {
+	// IRA holds 10 shares of VTI bought at $100, taxable holds 5 shares
+	// bought at $100; VTI now trades at $200/share, so the taxable
+	// account's sale realizes (200-100)*5 = $500 of gain, over its $400 cap.
+	taxable := &AccountState{
+		Name: "Taxable", TaxTreatment: "taxable", MaxRealizedGain: 400,
+		Balances: map[string]float64{"VTI": 1000},
+	}
+	positions := map[string]*Position{
+		"VTI": {Symbol: "VTI", Shares: 15, CostBasis: 1500}, // avg cost $100/share
+	}
+	pricePerShare := map[string]float64{"VTI": 200} // (2000 IRA + 1000 taxable) / 15 shares
+
+	allowed, note := capSellForTax(taxable, "VTI", 1000, positions, pricePerShare)
+	if allowed != 0 {
+		t.Errorf("allowed = %v, want 0: a $500 gain should be blocked by the $400 cap", allowed)
+	}
+	if note == "" {
+		t.Error("expected a note explaining the block")
+	}
+}
+
+func TestCapSellForTaxAllowsASellWithinTheCap(t *testing.T) {
+	taxable := &AccountState{
+		Name: "Taxable", TaxTreatment: "taxable", MaxRealizedGain: 1000,
+		Balances: map[string]float64{"VTI": 1000},
+	}
+	positions := map[string]*Position{
+		"VTI": {Symbol: "VTI", Shares: 15, CostBasis: 1500},
+	}
+	pricePerShare := map[string]float64{"VTI": 200}
+
+	allowed, note := capSellForTax(taxable, "VTI", 1000, positions, pricePerShare)
+	if allowed != 1000 {
+		t.Errorf("allowed = %v, want 1000: a $500 gain is within the $1000 cap", allowed)
+	}
+	if note != "" {
+		t.Errorf("note = %q, want empty: an allowed sell shouldn't need a block note", note)
+	}
+}
+
+func TestCapSellForTaxIgnoresNonTaxableAccounts(t *testing.T) {
+	ira := &AccountState{Name: "IRA", TaxTreatment: "ira", MaxRealizedGain: 1, Balances: map[string]float64{"VTI": 2000}}
+	positions := map[string]*Position{"VTI": {Symbol: "VTI", Shares: 15, CostBasis: 1500}}
+	pricePerShare := map[string]float64{"VTI": 200}
+
+	allowed, note := capSellForTax(ira, "VTI", 2000, positions, pricePerShare)
+	if allowed != 2000 {
+		t.Errorf("allowed = %v, want 2000: the cap only applies to taxable accounts", allowed)
+	}
+	if note != "" {
+		t.Errorf("note = %q, want empty", note)
+	}
+}
+
+func TestPlanAccountsFundsABuyFromASellInTheSameGroup(t *testing.T) {
+	profile := Profile{Buckets: []AssetBucket{
+		{Symbol: "Bonds", TargetPercentage: 0.5, DriftThreshold: 0},
+		{Symbol: "Stocks", TargetPercentage: 0.5, DriftThreshold: 0},
+	}}
+	accounts := []AccountState{
+		{
+			Name: "Taxable", TaxTreatment: "taxable", Profile: profile,
+			Balances: map[string]float64{"Bonds": 0, "Stocks": 1000},
+		},
+	}
+	positions := map[string]*Position{
+		"Stocks": {Symbol: "Stocks", Shares: 10, CostBasis: 1000}, // no gain, so the sell isn't capped
+	}
+
+	plans := PlanAccounts(accounts, positions)
+	if len(plans) != 1 {
+		t.Fatalf("got %d plans, want 1", len(plans))
+	}
+
+	var sawSell, sawBuy bool
+	for _, trade := range plans[0].Trades {
+		switch {
+		case trade.Symbol == "Stocks" && trade.Action == "Sell":
+			sawSell = true
+		case trade.Symbol == "Bonds" && trade.Action == "Buy":
+			sawBuy = true
+		}
+	}
+	if !sawSell {
+		t.Error("expected a Sell trade rebalancing Stocks down")
+	}
+	if !sawBuy {
+		t.Error("expected a Buy trade rebalancing Bonds up")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+)
+
+// defaultLedgerFile is where purchase/sale history is appended to and
+// read back from when the user doesn't point the GUI at a different path.
+const defaultLedgerFile = "ledger.jsonl"
+
+// Purchase is a single append-only ledger entry: a buy (positive Shares)
+// or a sell (negative Shares) of a symbol at a price, in some currency.
+// The ledger is the source of truth for cost basis, so entries are never
+// edited or removed, only appended.
+type Purchase struct {
+	Symbol   string  `json:"symbol"`
+	Date     string  `json:"date"`
+	Shares   float64 `json:"shares"`
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+}
+
+// LoadLedger reads every entry from a JSON-lines ledger file, one Purchase
+// per line. A missing file is treated as an empty ledger.
+func LoadLedger(filename string) ([]Purchase, error) {
+	file, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Purchase
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var p Purchase
+		if err := json.Unmarshal(line, &p); err != nil {
+			return nil, err
+		}
+		entries = append(entries, p)
+	}
+	return entries, scanner.Err()
+}
+
+// AppendPurchase writes one entry to the end of the ledger file without
+// reading or rewriting the rest of it.
+func AppendPurchase(filename string, p Purchase) error {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Position is the running cost-basis state for one symbol, derived by
+// replaying the ledger in order.
+type Position struct {
+	Symbol      string
+	Shares      float64
+	CostBasis   float64 // total cost of currently-held shares
+	RealizedPnL float64
+}
+
+// AvgCost returns the average cost per currently-held share, or 0 if none
+// are held.
+func (p Position) AvgCost() float64 {
+	if p.Shares == 0 {
+		return 0
+	}
+	return p.CostBasis / p.Shares
+}
+
+// ComputePositions replays ledger entries in order, maintaining an
+// average cost basis per symbol: buys add to shares and cost basis, sells
+// realize gain/loss against the average cost at the time of the sale.
+// Specific lot selection (FIFO/LIFO/HIFO) is handled separately when
+// choosing which shares a recommended sell should come from.
+func ComputePositions(entries []Purchase) map[string]*Position {
+	positions := map[string]*Position{}
+	for _, e := range entries {
+		pos, ok := positions[e.Symbol]
+		if !ok {
+			pos = &Position{Symbol: e.Symbol}
+			positions[e.Symbol] = pos
+		}
+
+		if e.Shares >= 0 {
+			pos.Shares += e.Shares
+			pos.CostBasis += e.Shares * e.Price
+			continue
+		}
+
+		sold := -e.Shares
+		avgCost := pos.AvgCost()
+		pos.RealizedPnL += (e.Price - avgCost) * sold
+		pos.Shares -= sold
+		pos.CostBasis -= avgCost * sold
+	}
+	return positions
+}
+
+// UnrealizedPnL returns the unrealized gain/loss and its percentage for a
+// position given the symbol's current market value.
+func (p Position) UnrealizedPnL(currentValue float64) (amount, percent float64) {
+	amount = currentValue - p.CostBasis
+	if p.CostBasis == 0 {
+		return amount, 0
+	}
+	return amount, amount / p.CostBasis * 100
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestComputePositionsTracksAverageCostAndRealizedPnL(t *testing.T) {
+	entries := []Purchase{
+		{Symbol: "VTI", Date: "2024-01-01", Shares: 10, Price: 100, Currency: "USD"},
+		{Symbol: "VTI", Date: "2024-02-01", Shares: 10, Price: 200, Currency: "USD"},
+		{Symbol: "VTI", Date: "2024-03-01", Shares: -5, Price: 300, Currency: "USD"},
+	}
+
+	positions := ComputePositions(entries)
+	pos := positions["VTI"]
+	if pos == nil {
+		t.Fatal("expected a VTI position")
+	}
+
+	// Avg cost before the sell is (10*100 + 10*200) / 20 = 150/share.
+	const wantAvgCostBeforeSell = 150.0
+	const wantRealizedPnL = (300 - wantAvgCostBeforeSell) * 5
+
+	if pos.Shares != 15 {
+		t.Errorf("Shares = %v, want 15", pos.Shares)
+	}
+	if pos.RealizedPnL != wantRealizedPnL {
+		t.Errorf("RealizedPnL = %v, want %v", pos.RealizedPnL, wantRealizedPnL)
+	}
+	wantCostBasis := wantAvgCostBeforeSell * 15
+	if pos.CostBasis != wantCostBasis {
+		t.Errorf("CostBasis = %v, want %v", pos.CostBasis, wantCostBasis)
+	}
+	if avg := pos.AvgCost(); avg != wantAvgCostBeforeSell {
+		t.Errorf("AvgCost() = %v, want %v", avg, wantAvgCostBeforeSell)
+	}
+}
+
+func TestComputePositionsSeparatesSymbols(t *testing.T) {
+	entries := []Purchase{
+		{Symbol: "VTI", Date: "2024-01-01", Shares: 10, Price: 100, Currency: "USD"},
+		{Symbol: "BND", Date: "2024-01-01", Shares: 20, Price: 50, Currency: "USD"},
+	}
+
+	positions := ComputePositions(entries)
+	if got := positions["VTI"].Shares; got != 10 {
+		t.Errorf("VTI Shares = %v, want 10", got)
+	}
+	if got := positions["BND"].Shares; got != 20 {
+		t.Errorf("BND Shares = %v, want 20", got)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// defaultHoldingsFile is where share counts are persisted so the tool can
+// price a position without re-reading the brokerage CSV every time.
+const defaultHoldingsFile = "holdings.json"
+
+// quoteTimeout bounds every provider's HTTP round trip, so a hung quote
+// endpoint can't block the live-refresh ticker goroutine indefinitely.
+const quoteTimeout = 10 * time.Second
+
+var quoteClient = &http.Client{Timeout: quoteTimeout}
+
+// PriceProvider fetches a current market price for a symbol. Each
+// implementation knows how to talk to one quote source; swapping providers
+// doesn't change anything else in AllocationCalculator.
+type PriceProvider interface {
+	Name() string
+	FetchPrice(symbol string) (float64, error)
+}
+
+// LoadHoldings reads a JSON file of symbol -> shares held, the quantities a
+// PriceProvider needs to turn a quote into a balance.
+func LoadHoldings(filename string) (map[string]float64, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return map[string]float64{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	holdings := map[string]float64{}
+	if err := json.Unmarshal(data, &holdings); err != nil {
+		return nil, err
+	}
+	return holdings, nil
+}
+
+// SaveHoldings writes share counts to filename in the JSON format
+// LoadHoldings reads.
+func SaveHoldings(filename string, holdings map[string]float64) error {
+	data, err := json.MarshalIndent(holdings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// YahooFinanceProvider fetches quotes from Yahoo Finance's public chart
+// endpoint, which doesn't require an API key.
+type YahooFinanceProvider struct{}
+
+func (YahooFinanceProvider) Name() string { return "Yahoo Finance" }
+
+func (YahooFinanceProvider) FetchPrice(symbol string) (float64, error) {
+	url := fmt.Sprintf("https://query1.finance.yahoo.com/v8/finance/chart/%s", symbol)
+	resp, err := quoteClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Chart struct {
+			Result []struct {
+				Meta struct {
+					RegularMarketPrice float64 `json:"regularMarketPrice"`
+				} `json:"meta"`
+			} `json:"result"`
+		} `json:"chart"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return 0, err
+	}
+	if len(payload.Chart.Result) == 0 {
+		return 0, fmt.Errorf("yahoo finance: no quote for %s", symbol)
+	}
+	return payload.Chart.Result[0].Meta.RegularMarketPrice, nil
+}
+
+// CoinGeckoProvider fetches quotes from CoinGecko's simple price endpoint,
+// for crypto symbols expressed as CoinGecko coin IDs (e.g. "bitcoin").
+type CoinGeckoProvider struct {
+	VsCurrency string // e.g. "usd"; defaults to "usd" if empty
+}
+
+func (CoinGeckoProvider) Name() string { return "CoinGecko" }
+
+func (p CoinGeckoProvider) FetchPrice(symbol string) (float64, error) {
+	vsCurrency := p.VsCurrency
+	if vsCurrency == "" {
+		vsCurrency = "usd"
+	}
+	url := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=%s", symbol, vsCurrency)
+	resp, err := quoteClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var payload map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return 0, err
+	}
+	quote, ok := payload[symbol]
+	if !ok {
+		return 0, fmt.Errorf("coingecko: no quote for %s", symbol)
+	}
+	price, ok := quote[vsCurrency]
+	if !ok {
+		return 0, fmt.Errorf("coingecko: no %s quote for %s", vsCurrency, symbol)
+	}
+	return price, nil
+}
+
+// AlphaVantageProvider fetches quotes from Alpha Vantage's GLOBAL_QUOTE
+// endpoint, which requires an API key.
+type AlphaVantageProvider struct {
+	APIKey string
+}
+
+func (AlphaVantageProvider) Name() string { return "Alpha Vantage" }
+
+func (p AlphaVantageProvider) FetchPrice(symbol string) (float64, error) {
+	url := fmt.Sprintf("https://www.alphavantage.co/query?function=GLOBAL_QUOTE&symbol=%s&apikey=%s", symbol, p.APIKey)
+	resp, err := quoteClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		GlobalQuote struct {
+			Price string `json:"05. price"`
+		} `json:"Global Quote"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return 0, err
+	}
+	if payload.GlobalQuote.Price == "" {
+		return 0, fmt.Errorf("alpha vantage: no quote for %s", symbol)
+	}
+	var price float64
+	if _, err := fmt.Sscanf(payload.GlobalQuote.Price, "%f", &price); err != nil {
+		return 0, err
+	}
+	return price, nil
+}
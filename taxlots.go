@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// LotPolicy picks which open tax lot a recommended sell draws shares from
+// first.
+type LotPolicy string
+
+const (
+	LotPolicyFIFO   LotPolicy = "FIFO"   // oldest lot first
+	LotPolicyLIFO   LotPolicy = "LIFO"   // newest lot first
+	LotPolicyHIFO   LotPolicy = "HIFO"   // highest cost basis first
+	LotPolicyMinTax LotPolicy = "MinTax" // lowest estimated tax first
+)
+
+const (
+	longTermHoldingDays = 365
+	washSaleWindowDays  = 30
+	shortTermTaxRate    = 0.37
+	longTermTaxRate     = 0.15
+)
+
+// Lot is a single open (not yet fully sold) purchase of a symbol.
+type Lot struct {
+	Symbol   string
+	Date     time.Time
+	Shares   float64
+	Price    float64
+	Currency string
+}
+
+// OpenLots replays the ledger in order and returns the lots still open
+// today, per symbol. Past sells are matched against lots FIFO, since the
+// ledger doesn't record which lot an earlier sell actually drew from;
+// LotPolicy only applies to sells being recommended now.
+func OpenLots(entries []Purchase) (map[string][]Lot, error) {
+	open := map[string][]Lot{}
+	for _, e := range entries {
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger date %q: %w", e.Date, err)
+		}
+
+		if e.Shares >= 0 {
+			open[e.Symbol] = append(open[e.Symbol], Lot{
+				Symbol: e.Symbol, Date: date, Shares: e.Shares, Price: e.Price, Currency: e.Currency,
+			})
+			continue
+		}
+
+		lots := open[e.Symbol]
+		remaining := -e.Shares
+		i := 0
+		for ; remaining > 0 && i < len(lots); i++ {
+			if lots[i].Shares > remaining {
+				lots[i].Shares -= remaining
+				remaining = 0
+				break
+			}
+			remaining -= lots[i].Shares
+			lots[i].Shares = 0
+		}
+		open[e.Symbol] = lots[i:]
+	}
+	return open, nil
+}
+
+// LotSale is one open lot (or part of one) a recommended sell draws from.
+type LotSale struct {
+	Lot        Lot
+	SharesSold float64
+	Proceeds   float64
+	GainLoss   float64
+	ShortTerm  bool
+	WashSale   bool
+}
+
+// SelectLots orders a symbol's open lots by policy and greedily consumes
+// them until `shares` is covered, reporting the realized gain/loss and
+// wash-sale exposure of each piece sold.
+func SelectLots(lots []Lot, shares float64, policy LotPolicy, salePrice float64, saleDate time.Time, allEntries []Purchase) []LotSale {
+	ordered := make([]Lot, len(lots))
+	copy(ordered, lots)
+	sortLotsForPolicy(ordered, policy, salePrice, saleDate)
+
+	var sales []LotSale
+	remaining := shares
+	for _, lot := range ordered {
+		if remaining <= 0 {
+			break
+		}
+		take := math.Min(lot.Shares, remaining)
+		holdingDays := saleDate.Sub(lot.Date).Hours() / 24
+		gainLoss := (salePrice - lot.Price) * take
+		sales = append(sales, LotSale{
+			Lot:        lot,
+			SharesSold: take,
+			Proceeds:   salePrice * take,
+			GainLoss:   gainLoss,
+			ShortTerm:  holdingDays < longTermHoldingDays,
+			// The wash-sale rule only disallows claiming a loss, not a gain.
+			WashSale: gainLoss < 0 && hasNearbyBuy(allEntries, lot, saleDate),
+		})
+		remaining -= take
+	}
+	return sales
+}
+
+func sortLotsForPolicy(lots []Lot, policy LotPolicy, salePrice float64, saleDate time.Time) {
+	switch policy {
+	case LotPolicyLIFO:
+		sort.Slice(lots, func(i, j int) bool { return lots[i].Date.After(lots[j].Date) })
+	case LotPolicyHIFO:
+		sort.Slice(lots, func(i, j int) bool { return lots[i].Price > lots[j].Price })
+	case LotPolicyMinTax:
+		sort.Slice(lots, func(i, j int) bool {
+			return estimatedTax(lots[i], salePrice, saleDate) < estimatedTax(lots[j], salePrice, saleDate)
+		})
+	default: // LotPolicyFIFO
+		sort.Slice(lots, func(i, j int) bool { return lots[i].Date.Before(lots[j].Date) })
+	}
+}
+
+// estimatedTax approximates the tax owed on selling one share of lot at
+// salePrice, using flat short/long-term rates, so MinTax can prefer lots
+// that owe the least (losses first, then the smallest long-term gains).
+func estimatedTax(lot Lot, salePrice float64, saleDate time.Time) float64 {
+	gainPerShare := salePrice - lot.Price
+	holdingDays := saleDate.Sub(lot.Date).Hours() / 24
+	rate := longTermTaxRate
+	if holdingDays < longTermHoldingDays {
+		rate = shortTermTaxRate
+	}
+	return gainPerShare * rate
+}
+
+// hasNearbyBuy reports whether a buy of the sold lot's symbol happened
+// within the wash-sale window of saleDate, in either direction, other than
+// the purchase that opened the lot being sold — buying the lot itself
+// isn't a "nearby" repurchase of it.
+func hasNearbyBuy(entries []Purchase, lot Lot, saleDate time.Time) bool {
+	for _, e := range entries {
+		if e.Symbol != lot.Symbol || e.Shares <= 0 {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			continue
+		}
+		if date.Equal(lot.Date) && e.Price == lot.Price {
+			continue
+		}
+		if math.Abs(saleDate.Sub(date).Hours()/24) <= washSaleWindowDays {
+			return true
+		}
+	}
+	return false
+}
+
+// YearlyRealizedGains is the short/long-term realized gain or loss for a
+// calendar year, grouped for tax reporting.
+type YearlyRealizedGains struct {
+	Year          int
+	ShortTermGain float64
+	LongTermGain  float64
+}
+
+// SummarizeRealizedGains replays every sell in the ledger against FIFO
+// lots and groups the resulting realized gain/loss by year and term.
+func SummarizeRealizedGains(entries []Purchase) ([]YearlyRealizedGains, error) {
+	open := map[string][]Lot{}
+	byYear := map[int]*YearlyRealizedGains{}
+
+	for _, e := range entries {
+		date, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger date %q: %w", e.Date, err)
+		}
+
+		if e.Shares >= 0 {
+			open[e.Symbol] = append(open[e.Symbol], Lot{
+				Symbol: e.Symbol, Date: date, Shares: e.Shares, Price: e.Price, Currency: e.Currency,
+			})
+			continue
+		}
+
+		lots := open[e.Symbol]
+		remaining := -e.Shares
+		i := 0
+		for ; remaining > 0 && i < len(lots); i++ {
+			take := math.Min(lots[i].Shares, remaining)
+			gain := (e.Price - lots[i].Price) * take
+
+			summary, ok := byYear[date.Year()]
+			if !ok {
+				summary = &YearlyRealizedGains{Year: date.Year()}
+				byYear[date.Year()] = summary
+			}
+			if date.Sub(lots[i].Date).Hours()/24 < longTermHoldingDays {
+				summary.ShortTermGain += gain
+			} else {
+				summary.LongTermGain += gain
+			}
+
+			lots[i].Shares -= take
+			remaining -= take
+			if lots[i].Shares > 0 {
+				break
+			}
+		}
+		if i < len(lots) {
+			open[e.Symbol] = lots[i:]
+		} else {
+			open[e.Symbol] = nil
+		}
+	}
+
+	years := make([]int, 0, len(byYear))
+	for y := range byYear {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	result := make([]YearlyRealizedGains, len(years))
+	for i, y := range years {
+		result[i] = *byYear[y]
+	}
+	return result, nil
+}
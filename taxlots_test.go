@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseDate(t *testing.T, date string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		t.Fatalf("invalid date %q: %v", date, err)
+	}
+	return parsed
+}
+
+func TestSelectLotsDoesNotFlagTheSoldLotsOwnPurchaseAsAWashSale(t *testing.T) {
+	entries := []Purchase{
+		{Symbol: "VTI", Date: "2024-01-01", Shares: 10, Price: 100, Currency: "USD"},
+	}
+	lots := []Lot{
+		{Symbol: "VTI", Date: mustParseDate(t, "2024-01-01"), Shares: 10, Price: 100, Currency: "USD"},
+	}
+
+	// Sell at a loss two weeks after the only purchase on record, with no
+	// other transactions anywhere near the wash-sale window.
+	saleDate := mustParseDate(t, "2024-01-15")
+	sales := SelectLots(lots, 10, LotPolicyFIFO, 80, saleDate, entries)
+
+	if len(sales) != 1 {
+		t.Fatalf("got %d sales, want 1", len(sales))
+	}
+	if sales[0].WashSale {
+		t.Error("WashSale = true, want false: the only nearby buy is the lot's own purchase")
+	}
+}
+
+func TestSelectLotsFlagsARealNearbyRepurchaseAsAWashSale(t *testing.T) {
+	entries := []Purchase{
+		{Symbol: "VTI", Date: "2024-01-01", Shares: 10, Price: 100, Currency: "USD"},
+		{Symbol: "VTI", Date: "2024-01-20", Shares: 5, Price: 90, Currency: "USD"},
+	}
+	lots := []Lot{
+		{Symbol: "VTI", Date: mustParseDate(t, "2024-01-01"), Shares: 10, Price: 100, Currency: "USD"},
+	}
+
+	saleDate := mustParseDate(t, "2024-01-15")
+	sales := SelectLots(lots, 10, LotPolicyFIFO, 80, saleDate, entries)
+
+	if len(sales) != 1 {
+		t.Fatalf("got %d sales, want 1", len(sales))
+	}
+	if !sales[0].WashSale {
+		t.Error("WashSale = false, want true: a distinct repurchase falls inside the 30-day window")
+	}
+}
+
+func TestSelectLotsNeverFlagsAGainAsAWashSale(t *testing.T) {
+	entries := []Purchase{
+		{Symbol: "VTI", Date: "2024-01-01", Shares: 10, Price: 100, Currency: "USD"},
+		{Symbol: "VTI", Date: "2024-01-20", Shares: 5, Price: 90, Currency: "USD"},
+	}
+	lots := []Lot{
+		{Symbol: "VTI", Date: mustParseDate(t, "2024-01-01"), Shares: 10, Price: 100, Currency: "USD"},
+	}
+
+	saleDate := mustParseDate(t, "2024-01-15")
+	// Sell at a gain (salePrice 120 > lot price 100); the nearby repurchase
+	// on 2024-01-20 would otherwise trip the window check.
+	sales := SelectLots(lots, 10, LotPolicyFIFO, 120, saleDate, entries)
+
+	if len(sales) != 1 {
+		t.Fatalf("got %d sales, want 1", len(sales))
+	}
+	if sales[0].WashSale {
+		t.Error("WashSale = true, want false: the wash-sale rule only disallows claiming a loss")
+	}
+}